@@ -0,0 +1,208 @@
+// Package grpcserver adapts handler.ExifTool onto the gRPC service defined
+// in proto/exif.proto, reusing the same process-lifecycle plumbing the HTTP
+// transport uses in package handler.
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton-dessiatov/exifserve/handler"
+	"github.com/anton-dessiatov/exifserve/proto"
+)
+
+// Server implements proto.ExifServer.
+type Server struct {
+	proto.UnimplementedExifServer
+
+	cfg     handler.Config
+	limiter *handler.ExtractLimiter
+}
+
+// NewServer constructs a Server from cfg and the extract limiter shared with
+// the HTTP and WebSocket transports (see handler.Server.ExtractLimiter), so
+// all three stay under the same cfg.MaxConcurrentExtractions cap.
+func NewServer(cfg handler.Config, limiter *handler.ExtractLimiter) *Server {
+	return &Server{cfg: cfg, limiter: limiter}
+}
+
+// ListTags implements proto.ExifServer by streaming one Tag message per
+// decoded exiftool tag, mirroring GetTags on the HTTP side.
+func (s *Server) ListTags(_ *proto.ListTagsRequest, stream proto.Exif_ListTagsServer) error {
+	ctx, cancel := context.WithTimeout(stream.Context(), s.cfg.RequestTimeout)
+	defer cancel()
+
+	et := handler.Listx(ctx, s.cfg.ExiftoolPath)
+	defer et.Close()
+	if err := et.Start(); err != nil {
+		return fmt.Errorf("et.Start: %w", err)
+	}
+
+	err := et.StreamTagsFunc(func(tag handler.Tag) error {
+		return stream.Send(toProtoTag(tag))
+	})
+	if err != nil {
+		return fmt.Errorf("et.StreamTagsFunc: %w", err)
+	}
+
+	return nil
+}
+
+// Extract implements proto.ExifServer by spooling the streamed FileChunks to
+// a scratch directory, running exiftool once the client closes the upload
+// stream, and sending back one FileMetadata message per file, mirroring
+// ExtractHandler on the HTTP side.
+func (s *Server) Extract(stream proto.Exif_ExtractServer) error {
+	release, ok := s.limiter.TryAcquire()
+	if !ok {
+		return status.Error(codes.ResourceExhausted, "too many concurrent extractions")
+	}
+	defer release()
+
+	if err := os.MkdirAll(s.cfg.WorkRoot, 0o700); err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+	// The "req-" prefix is the one handler.SweepStaleDirs removes.
+	workDir, err := os.MkdirTemp(s.cfg.WorkRoot, "req-grpc-")
+	if err != nil {
+		return fmt.Errorf("os.MkdirTemp: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	paths, err := spoolChunks(workDir, stream, s.cfg.MaxFileSize, s.cfg.MaxUploadSize)
+	if err != nil {
+		return fmt.Errorf("spoolChunks: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(stream.Context(), s.cfg.ExtractTimeout)
+	defer cancel()
+
+	args := append([]string{"-j", "-a", "-G"}, paths...)
+	et := handler.Run(ctx, s.cfg.ExiftoolPath, args...)
+	defer et.Close()
+	if err := et.Start(); err != nil {
+		return fmt.Errorf("et.Start: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := et.StreamRaw(&out); err != nil {
+		return fmt.Errorf("et.StreamRaw: %w", err)
+	}
+
+	metadata, err := decodeFileMetadata(out.Bytes())
+	if err != nil {
+		return fmt.Errorf("decodeFileMetadata: %w", err)
+	}
+	for _, m := range metadata {
+		if err := stream.Send(m); err != nil {
+			return fmt.Errorf("stream.Send: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// spoolChunks reads FileChunks off stream until the client closes it, and
+// returns the resulting file paths in upload order. As documented on
+// FileChunk.filename, it's only set on the first chunk of each file and may
+// be omitted on the rest, so (mirroring wsSpoolUploads in handler/ws.go)
+// spoolChunks tracks a single "current" file and only switches to a new one
+// when it sees a non-empty filename, rather than keying by filename (which
+// would collide every continuation chunk on the "" key). maxFileSize and
+// maxUploadSize are enforced the same way the HTTP and WebSocket transports
+// do, since there's no Content-Length to cap up front on a gRPC stream.
+func spoolChunks(dir string, stream proto.Exif_ExtractServer, maxFileSize, maxUploadSize int64) ([]string, error) {
+	var paths []string
+	var current *os.File
+	var currentSize, totalSize int64
+	defer func() {
+		if current != nil {
+			current.Close()
+		}
+	}()
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return paths, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("stream.Recv: %w", err)
+		}
+
+		if chunk.Filename != "" {
+			if current != nil {
+				current.Close()
+			}
+			path := filepath.Join(dir, filepath.Base(chunk.Filename))
+			current, err = os.Create(path)
+			if err != nil {
+				return nil, fmt.Errorf("os.Create: %w", err)
+			}
+			currentSize = 0
+			paths = append(paths, path)
+		}
+		if current == nil {
+			return nil, errors.New("chunk received before a filename was set")
+		}
+
+		currentSize += int64(len(chunk.Data))
+		if currentSize > maxFileSize {
+			return nil, fmt.Errorf("upload exceeds the per-file size limit of %d bytes", maxFileSize)
+		}
+		totalSize += int64(len(chunk.Data))
+		if totalSize > maxUploadSize {
+			return nil, fmt.Errorf("upload exceeds the total size limit of %d bytes", maxUploadSize)
+		}
+
+		if _, err := current.Write(chunk.Data); err != nil {
+			return nil, fmt.Errorf("current.Write: %w", err)
+		}
+	}
+}
+
+// decodeFileMetadata parses the JSON array exiftool's `-j` produces into one
+// FileMetadata per file, flattening each file's tag values to strings.
+func decodeFileMetadata(jsonOutput []byte) ([]*proto.FileMetadata, error) {
+	var files []map[string]interface{}
+	if err := json.Unmarshal(jsonOutput, &files); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	result := make([]*proto.FileMetadata, 0, len(files))
+	for _, file := range files {
+		m := &proto.FileMetadata{
+			Tags: make(map[string]string, len(file)),
+		}
+		for k, v := range file {
+			if k == "SourceFile" {
+				m.Filename = filepath.Base(fmt.Sprintf("%v", v))
+				continue
+			}
+			m.Tags[k] = fmt.Sprintf("%v", v)
+		}
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// toProtoTag converts a handler.Tag into its wire representation.
+func toProtoTag(tag handler.Tag) *proto.Tag {
+	return &proto.Tag{
+		Path:        tag.Path,
+		Group:       tag.Group,
+		Type:        tag.Type,
+		Writable:    tag.Writable,
+		Description: tag.Description,
+	}
+}
@@ -0,0 +1,92 @@
+package grpcserver
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/anton-dessiatov/exifserve/proto"
+)
+
+// fakeExtractServer is a minimal proto.Exif_ExtractServer for exercising
+// spoolChunks without a real gRPC connection. It implements Recv, which is
+// all spoolChunks calls, and a no-op Send; the embedded grpc.ServerStream
+// supplies the rest of the interface.
+type fakeExtractServer struct {
+	grpc.ServerStream
+
+	chunks []*proto.FileChunk
+	i      int
+}
+
+func (f *fakeExtractServer) Recv() (*proto.FileChunk, error) {
+	if f.i >= len(f.chunks) {
+		return nil, io.EOF
+	}
+	c := f.chunks[f.i]
+	f.i++
+	return c, nil
+}
+
+func (f *fakeExtractServer) Send(*proto.FileMetadata) error { return nil }
+
+func TestSpoolChunksContinuationChunksOmitFilename(t *testing.T) {
+	dir := t.TempDir()
+	stream := &fakeExtractServer{chunks: []*proto.FileChunk{
+		{Filename: "a.jpg", Data: []byte("AAAA")},
+		{Data: []byte("BBBB")},
+		{Filename: "b.jpg", Data: []byte("CC")},
+		{Data: []byte("DD")},
+	}}
+
+	paths, err := spoolChunks(dir, stream, 1<<20, 1<<20)
+	if err != nil {
+		t.Fatalf("spoolChunks: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("spoolChunks returned %d paths, want 2: %v", len(paths), paths)
+	}
+
+	a, err := os.ReadFile(filepath.Join(dir, "a.jpg"))
+	if err != nil {
+		t.Fatalf("os.ReadFile a.jpg: %v", err)
+	}
+	if string(a) != "AAAABBBB" {
+		t.Fatalf("a.jpg contents = %q, want %q", a, "AAAABBBB")
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "b.jpg"))
+	if err != nil {
+		t.Fatalf("os.ReadFile b.jpg: %v", err)
+	}
+	if string(b) != "CCDD" {
+		t.Fatalf("b.jpg contents = %q, want %q", b, "CCDD")
+	}
+}
+
+func TestSpoolChunksEnforcesPerFileSizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	stream := &fakeExtractServer{chunks: []*proto.FileChunk{
+		{Filename: "a.jpg", Data: []byte("AAAA")},
+		{Data: []byte("BBBB")},
+	}}
+
+	if _, err := spoolChunks(dir, stream, 5, 1<<20); err == nil {
+		t.Fatal("spoolChunks: expected an error exceeding the per-file size limit, got nil")
+	}
+}
+
+func TestSpoolChunksEnforcesTotalUploadSizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	stream := &fakeExtractServer{chunks: []*proto.FileChunk{
+		{Filename: "a.jpg", Data: []byte("AAAA")},
+		{Filename: "b.jpg", Data: []byte("BBBB")},
+	}}
+
+	if _, err := spoolChunks(dir, stream, 1<<20, 5); err == nil {
+		t.Fatal("spoolChunks: expected an error exceeding the total upload size limit, got nil")
+	}
+}
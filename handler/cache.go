@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// tagsCache caches the result of `exiftool -listx`, which is static per
+// installed exiftool version, so GetTags doesn't have to re-fork exiftool
+// and re-parse XML on every request — an easy DoS vector and pointlessly
+// slow otherwise.
+type tagsCache struct {
+	exiftoolPath string
+	// populateTimeout bounds the shared populate call below, independent of
+	// any one caller's context: it runs under singleflight on behalf of
+	// however many requests piggybacked on it, so it must not be tied to
+	// (and killed by) whichever one of them happened to arrive first.
+	populateTimeout time.Duration
+
+	group singleflight.Group
+
+	mu           sync.RWMutex
+	tags         []Tag
+	jsonBody     []byte
+	etag         string
+	lastModified time.Time
+}
+
+func newTagsCache(exiftoolPath string, populateTimeout time.Duration) *tagsCache {
+	return &tagsCache{exiftoolPath: exiftoolPath, populateTimeout: populateTimeout}
+}
+
+// tagsCacheEntry is what a single populate call produces and what get
+// returns; it exists so a burst of cold-cache callers sharing one
+// singleflight.Group.Do call all get the same consistent snapshot.
+type tagsCacheEntry struct {
+	tags         []Tag
+	jsonBody     []byte
+	etag         string
+	lastModified time.Time
+}
+
+// get returns the cached tags, their JSON encoding, and cache-validation
+// metadata, populating the cache first if it's empty. Concurrent callers
+// hitting a cold cache share a single exiftool invocation via singleflight.
+func (c *tagsCache) get(ctx context.Context) (*tagsCacheEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	if c.jsonBody != nil {
+		entry := &tagsCacheEntry{
+			tags:         c.tags,
+			jsonBody:     c.jsonBody,
+			etag:         c.etag,
+			lastModified: c.lastModified,
+		}
+		c.mu.RUnlock()
+		return entry, nil
+	}
+	c.mu.RUnlock()
+
+	v, err, _ := c.group.Do("listx", func() (interface{}, error) {
+		// populate runs on behalf of every caller sharing this singleflight
+		// call, not just whichever one happens to be its leader, so it must
+		// not inherit that leader's ctx: if the leader's request times out
+		// or disconnects, its ctx cancellation would kill the exiftool
+		// process (and the populate call) for every other caller
+		// piggybacking on it even though their own contexts are still fine.
+		popCtx, cancel := context.WithTimeout(context.Background(), c.populateTimeout)
+		defer cancel()
+		return c.populate(popCtx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*tagsCacheEntry), nil
+}
+
+// populate runs `exiftool -listx` (and `-ver`), stores the result, and
+// returns it.
+func (c *tagsCache) populate(ctx context.Context) (*tagsCacheEntry, error) {
+	et := Listx(ctx, c.exiftoolPath)
+	defer et.Close()
+	if err := et.Start(); err != nil {
+		return nil, fmt.Errorf("et.Start: %w", err)
+	}
+
+	var tags []Tag
+	if err := et.StreamTagsFunc(func(tag Tag) error {
+		tags = append(tags, tag)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("et.StreamTagsFunc: %w", err)
+	}
+
+	body, err := json.Marshal(struct {
+		Tags []Tag `json:"tags"`
+	}{Tags: tags})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	// Fold the exiftool version into the ETag so an exiftool upgrade
+	// naturally invalidates clients' cached copies even if we forget to hit
+	// /admin/refresh.
+	version, err := exiftoolVersion(ctx, c.exiftoolPath)
+	if err != nil {
+		return nil, fmt.Errorf("exiftoolVersion: %w", err)
+	}
+
+	entry := &tagsCacheEntry{
+		tags:         tags,
+		jsonBody:     body,
+		etag:         fmt.Sprintf(`"%s-%x"`, version, sha256.Sum256(body)),
+		lastModified: time.Now(),
+	}
+
+	c.mu.Lock()
+	c.tags, c.jsonBody, c.etag, c.lastModified = entry.tags, entry.jsonBody, entry.etag, entry.lastModified
+	c.mu.Unlock()
+
+	return entry, nil
+}
+
+// invalidate drops the cached entry so the next get call repopulates it.
+func (c *tagsCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tags = nil
+	c.jsonBody = nil
+	c.etag = ""
+	c.lastModified = time.Time{}
+}
+
+// exiftoolVersion runs `exiftool -ver` and returns its trimmed output.
+func exiftoolVersion(ctx context.Context, exiftoolPath string) (string, error) {
+	et := Run(ctx, exiftoolPath, "-ver")
+	defer et.Close()
+	if err := et.Start(); err != nil {
+		return "", fmt.Errorf("et.Start: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := et.StreamRaw(&out); err != nil {
+		return "", fmt.Errorf("et.StreamRaw: %w", err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeExiftool writes a shell script standing in for the real exiftool
+// binary: "-ver" answers with a fixed version, "-listx" answers with a
+// single-tag table and appends a line to countPath so tests can observe how
+// many times it actually ran.
+func newFakeExiftool(t *testing.T, countPath string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "exiftool")
+	script := fmt.Sprintf(`#!/bin/sh
+case "$1" in
+  -ver)
+    echo "12.34"
+    ;;
+  -listx)
+    echo x >> %q
+    cat <<'XML'
+<exiftool><table name="EXIF"><tag name="Make" type="string" writable="true"><desc lang="en">Manufacturer</desc></tag></table></exiftool>
+XML
+    ;;
+esac
+`, countPath)
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	return path
+}
+
+func countFakeExiftoolCalls(t *testing.T, countPath string) int {
+	t.Helper()
+
+	data, err := os.ReadFile(countPath)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	return strings.Count(string(data), "\n")
+}
+
+func TestTagsCacheGetPopulatesAndReusesCache(t *testing.T) {
+	countPath := filepath.Join(t.TempDir(), "calls")
+	c := newTagsCache(newFakeExiftool(t, countPath), 5*time.Second)
+
+	entry, err := c.get(context.Background())
+	if err != nil {
+		t.Fatalf("c.get: %v", err)
+	}
+	if len(entry.tags) != 1 || entry.tags[0].Path != "EXIF:Make" {
+		t.Fatalf("unexpected tags: %+v", entry.tags)
+	}
+	if entry.etag == "" {
+		t.Fatal("expected a non-empty ETag")
+	}
+
+	if _, err := c.get(context.Background()); err != nil {
+		t.Fatalf("c.get (second call): %v", err)
+	}
+
+	if calls := countFakeExiftoolCalls(t, countPath); calls != 1 {
+		t.Fatalf("exiftool -listx ran %d times, want 1 (second get should hit the cache)", calls)
+	}
+}
+
+func TestTagsCacheInvalidateRepopulates(t *testing.T) {
+	countPath := filepath.Join(t.TempDir(), "calls")
+	c := newTagsCache(newFakeExiftool(t, countPath), 5*time.Second)
+
+	if _, err := c.get(context.Background()); err != nil {
+		t.Fatalf("c.get: %v", err)
+	}
+
+	c.invalidate()
+
+	if _, err := c.get(context.Background()); err != nil {
+		t.Fatalf("c.get after invalidate: %v", err)
+	}
+
+	if calls := countFakeExiftoolCalls(t, countPath); calls != 2 {
+		t.Fatalf("exiftool -listx ran %d times, want 2 (one per populate)", calls)
+	}
+}
+
+func TestTagsCacheGetRejectsCanceledContext(t *testing.T) {
+	countPath := filepath.Join(t.TempDir(), "calls")
+	c := newTagsCache(newFakeExiftool(t, countPath), 5*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.get(ctx); err == nil {
+		t.Fatal("c.get with an already-canceled context: expected an error, got nil")
+	}
+	if calls := countFakeExiftoolCalls(t, countPath); calls != 0 {
+		t.Fatalf("exiftool -listx ran %d times, want 0", calls)
+	}
+}
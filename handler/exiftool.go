@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
-	"sync"
 )
 
 // ExifTool is a wrapper around running exiftool binary and it's streams
@@ -20,14 +19,25 @@ type ExifTool struct {
 	stdout io.ReadCloser
 }
 
-// Listx returns an `exiftool -listx` command ready to get started
-func Listx(ctx context.Context) *ExifTool {
+// Run starts building an exiftool invocation with the given arguments,
+// returning an ExifTool ready to get started. This is the shared
+// process-lifecycle plumbing (cancel on decode error, Wait once reading is
+// done, no leaked children) for every exiftool invocation the handler package
+// makes, whether that's `-listx` or a per-file extraction. ctx is expected
+// to already carry whatever deadline the caller wants enforced; Run itself
+// only derives a cancelable context so that streaming errors can kill the
+// process early.
+func Run(ctx context.Context, exiftoolPath string, args ...string) *ExifTool {
 	ctx, cancel := context.WithCancel(ctx)
 	return &ExifTool{
-		cmd:    exec.CommandContext(ctx, "exiftool", "-listx"),
+		cmd:    exec.CommandContext(ctx, exiftoolPath, args...),
 		cancel: cancel,
 	}
+}
 
+// Listx returns an `exiftool -listx` command ready to get started
+func Listx(ctx context.Context, exiftoolPath string) *ExifTool {
+	return Run(ctx, exiftoolPath, "-listx")
 }
 
 // Close closes the exiftool command
@@ -53,81 +63,127 @@ func (et *ExifTool) Start() error {
 	return nil
 }
 
-// StreamTags streams tags from a running exiftool to a given writer.
-// exiftool must be started
-func (et *ExifTool) StreamTags(w io.Writer) (err error) {
-	decoder := xml.NewDecoder(et.stdout)
+// Tag describes a single exiftool tag, as listed by `exiftool -listx`. It's
+// the unit the HTTP, gRPC and WebSocket transports each render in their own
+// way.
+type Tag struct {
+	Path        string            `json:"path"`
+	Group       string            `json:"group"`
+	Type        string            `json:"type"`
+	Writable    bool              `json:"writable"`
+	Description map[string]string `json:"description"`
+}
 
-	var wg sync.WaitGroup
+// StreamTags streams tags from a running exiftool to a given writer as a
+// JSON array. exiftool must be started
+func (et *ExifTool) StreamTags(w io.Writer) error {
+	enc := json.NewEncoder(w)
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		s := &streamer{
-			Decoder: decoder,
-			Writer:  w,
-			Encoder: json.NewEncoder(w),
-		}
+	_, err := w.Write([]byte(`{"tags": [`))
+	if err != nil {
+		return fmt.Errorf("w.Write: %w", err)
+	}
 
-		prologErr := s.emitProlog()
-		if prologErr != nil {
-			err = fmt.Errorf("s.emitProlog: %w", prologErr)
-			return
+	comma := false
+	streamErr := et.StreamTagsFunc(func(tag Tag) error {
+		if comma {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return fmt.Errorf("w.Write: %w", err)
+			}
 		}
+		comma = true
+		return enc.Encode(tag)
+	})
+	if streamErr != nil {
+		return streamErr
+	}
 
-		// We don't need to pass context down the streaming function because if
-		// the request is canceled (network connection is broken), ExifTool context
-		// would be cancelled, which would terminate exiftool process and subsequent
-		// streaming reads will fail, so s.stream would exit (albeit with an error)
-		streamErr := s.stream()
-		if errors.Is(streamErr, io.EOF) {
-			// That's okay, we've just reached the end of input. I'm treating EOF
-			// in functions down the call chain like any other error to avoid
-			// cluttering the code with io.EOF checks.
+	if _, err := w.Write([]byte(`]}`)); err != nil {
+		return fmt.Errorf("w.Write: %w", err)
+	}
 
-			epilogErr := s.emitEpilog()
-			if epilogErr != nil {
-				err = fmt.Errorf("s.emitEpilog: %w", epilogErr)
-				return
-			}
+	return nil
+}
 
-			return
-		}
-		if streamErr != nil {
-			err = fmt.Errorf("stream: %w", streamErr)
-			// Cancel the process context to make sure that process is killed
-			// as soon as possible
-			et.cancel()
-			return
-		}
-	}()
+// StreamTagsFunc decodes tags from a running exiftool's `-listx` output,
+// calling emit once per tag in document order. exiftool must be started.
+// HTTP renders each Tag as part of a JSON array (see tagsCache.populate), while the
+// gRPC and WebSocket transports send one message per Tag as emit is called.
+func (et *ExifTool) StreamTagsFunc(emit func(Tag) error) (err error) {
+	decoder := xml.NewDecoder(et.stdout)
+	s := &streamer{
+		Decoder: decoder,
+		Emit:    emit,
+	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		waitErr := et.cmd.Wait()
-		// In case there already is an error we don't overwrite it because it's
-		// either "signal: killed" because of the context cancellation above or
-		// an extremely rare case of having two errors simultaneously (one in
-		// streamer, another in OS/exiftool) and for simplicity we just prefer the
-		// streamer one.
-		//
-		// In a production code, I would have multierr.Combine here for the
-		// case when both errors are not nil
-		if waitErr != nil && err == nil {
-			err = fmt.Errorf("et.cmd.Wait: %w", waitErr)
-		}
-	}()
+	// We don't need to pass context down the streaming function because if
+	// the request is canceled (network connection is broken), ExifTool context
+	// would be cancelled, which would terminate exiftool process and subsequent
+	// streaming reads will fail, so s.stream would exit (albeit with an error)
+	streamErr := s.stream()
+	if errors.Is(streamErr, io.EOF) {
+		// That's okay, we've just reached the end of input. I'm treating EOF
+		// in functions down the call chain like any other error to avoid
+		// cluttering the code with io.EOF checks.
+	} else if streamErr != nil {
+		err = fmt.Errorf("stream: %w", streamErr)
+		// Cancel the process context to make sure that process is killed
+		// as soon as possible
+		et.cancel()
+	}
+
+	// cmd.Wait closes et.stdout once the process exits, so it must run after
+	// s.stream is done reading from it, not concurrently with it — otherwise
+	// Wait can close the pipe out from under a still-in-progress Read.
+	waitErr := et.cmd.Wait()
+	// In case there already is an error we don't overwrite it because it's
+	// either "signal: killed" because of the context cancellation above or
+	// an extremely rare case of having two errors simultaneously (one in
+	// streamer, another in OS/exiftool) and for simplicity we just prefer the
+	// streamer one.
+	//
+	// In a production code, I would have multierr.Combine here for the
+	// case when both errors are not nil
+	if waitErr != nil && err == nil {
+		err = fmt.Errorf("et.cmd.Wait: %w", waitErr)
+	}
 
-	wg.Wait()
+	return
+}
+
+// StreamRaw copies a running exiftool's stdout straight to w, applying the
+// same process-lifecycle discipline as StreamTags (Wait only after the copy
+// finishes reading et.stdout, cancellation of the underlying process if the
+// copy fails). It's meant for invocations whose output is already in the
+// desired wire format, such as `-j` extraction results, where there's no XML
+// to decode.
+func (et *ExifTool) StreamRaw(w io.Writer) (err error) {
+	_, copyErr := io.Copy(w, et.stdout)
+	if copyErr != nil {
+		err = fmt.Errorf("io.Copy: %w", copyErr)
+		// Cancel the process context to make sure that process is killed
+		// as soon as possible
+		et.cancel()
+	}
+
+	// See StreamTagsFunc: cmd.Wait closes et.stdout once the process exits,
+	// so it must run after the copy above is done reading from it.
+	waitErr := et.cmd.Wait()
+	// Same rationale as in StreamTagsFunc: prefer the copy error if both are
+	// present.
+	if waitErr != nil && err == nil {
+		err = fmt.Errorf("et.cmd.Wait: %w", waitErr)
+	}
 
 	return
 }
 
 type streamer struct {
 	Decoder *xml.Decoder
-	Writer  io.Writer
-	Encoder *json.Encoder
+	// Emit is called once per decoded Tag, in document order. The HTTP,
+	// gRPC and WebSocket transports each supply their own Emit to render a
+	// Tag their own way.
+	Emit func(Tag) error
 }
 
 func (s *streamer) stream() (err error) {
@@ -147,24 +203,7 @@ func (s *streamer) stream() (err error) {
 	}
 }
 
-func (s *streamer) emitProlog() error {
-	_, err := s.Writer.Write([]byte(`{"tags": [`))
-	if err != nil {
-		return fmt.Errorf("s.Writer.Write: %w", err)
-	}
-	return nil
-}
-
-func (s *streamer) emitEpilog() error {
-	_, err := s.Writer.Write([]byte(`]}`))
-	if err != nil {
-		return fmt.Errorf("s.Writer.Write: %w", err)
-	}
-	return nil
-}
-
 func (s *streamer) streamTags(tableName string) error {
-	comma := false
 	for {
 		res, err := search(xmlQuery{
 			NamedStart: "tag",
@@ -178,52 +217,47 @@ func (s *streamer) streamTags(tableName string) error {
 			return nil
 		}
 		if res.NamedStart != nil {
-			if comma {
-				_, err := s.Writer.Write([]byte(","))
-				if err != nil {
-					return fmt.Errorf("s.Writer.Write: %w", err)
-				}
-			}
-			err := s.emitTag(tableName, res.NamedStart)
+			tag, err := decodeTag(tableName, s.Decoder, res.NamedStart)
 			if err != nil {
-				return fmt.Errorf("s.emitTag: %w", err)
+				return fmt.Errorf("decodeTag: %w", err)
+			}
+			if err := s.Emit(tag); err != nil {
+				return fmt.Errorf("s.Emit: %w", err)
 			}
 		}
-		comma = true
 	}
 }
 
-func (s *streamer) emitTag(tableName string, se *xml.StartElement) error {
+// decodeTag decodes the `<tag>` element se (belonging to table tableName)
+// into a Tag value.
+func decodeTag(tableName string, decoder *xml.Decoder, se *xml.StartElement) (Tag, error) {
 	type xmlDesc struct {
 		Lang  string `xml:"lang,attr"`
 		Value string `xml:",chardata"`
 	}
-	var tag struct {
-		Name            string            `xml:"name,attr"json:"-"`
-		Writable        bool              `xml:"writable,attr"json:"writable"`
-		Path            string            `json:"path"`
-		Group           string            `json:"group"`
-		Description     []xmlDesc         `xml:"desc"json:"-"`
-		JSONDescription map[string]string `json:"description"`
-		Type            string            `xml:"type,attr"json:"type"`
+	var xmlTag struct {
+		Name        string    `xml:"name,attr"`
+		Writable    bool      `xml:"writable,attr"`
+		Type        string    `xml:"type,attr"`
+		Description []xmlDesc `xml:"desc"`
 	}
-	err := s.Decoder.DecodeElement(&tag, se)
-	if err != nil {
-		return fmt.Errorf("s.Decoder.DecodeElement: %w", err)
+	if err := decoder.DecodeElement(&xmlTag, se); err != nil {
+		return Tag{}, fmt.Errorf("decoder.DecodeElement: %w", err)
 	}
-	tag.Path = tableName + ":" + tag.Name
-	// I wasn't sure about this, there is no explicit statement about where
-	// the value for Group comes from
-	tag.Group = tableName
-	tag.JSONDescription = make(map[string]string)
-	for _, d := range tag.Description {
-		tag.JSONDescription[d.Lang] = d.Value
+
+	tag := Tag{
+		Path: tableName + ":" + xmlTag.Name,
+		// I wasn't sure about this, there is no explicit statement about
+		// where the value for Group comes from
+		Group:       tableName,
+		Type:        xmlTag.Type,
+		Writable:    xmlTag.Writable,
+		Description: make(map[string]string),
 	}
-	err = s.Encoder.Encode(tag)
-	if err != nil {
-		return fmt.Errorf("s.Encoder.Encode: %w", err)
+	for _, d := range xmlTag.Description {
+		tag.Description[d.Lang] = d.Value
 	}
-	return nil
+	return tag, nil
 }
 
 type xmlQuery struct {
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// scratchDirPrefix is the prefix SweepStaleDirs looks for so it never
+// touches unrelated entries that might live under a WorkRoot.
+const scratchDirPrefix = "req-"
+
+// ExtractLimiter bounds how many exiftool extraction processes may run at
+// once. It's shared across the HTTP, gRPC and WebSocket transports (see
+// Server.ExtractLimiter) so a client can't exceed the configured
+// concurrency cap just by picking a different transport.
+type ExtractLimiter struct {
+	slots chan struct{}
+}
+
+// NewExtractLimiter returns an ExtractLimiter allowing up to n concurrent
+// extractions.
+func NewExtractLimiter(n int) *ExtractLimiter {
+	return &ExtractLimiter{slots: make(chan struct{}, n)}
+}
+
+// TryAcquire reserves a slot without blocking. If the limiter is already at
+// capacity it returns ok == false and reserves nothing. Otherwise the caller
+// must call release (typically via defer) once the extraction is done.
+func (l *ExtractLimiter) TryAcquire() (release func(), ok bool) {
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, true
+	default:
+		return nil, false
+	}
+}
+
+// ExtractHandler is the HTTP handler function for the /extract POST method.
+// It spools each uploaded file to a unique scratch directory, runs exiftool
+// against the spooled files, and streams the result back as a JSON array.
+func (s *Server) ExtractHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	release, ok := s.extractLimiter.TryAcquire()
+	if !ok {
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.ExtractTimeout)
+	defer cancel()
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.cfg.MaxUploadSize)
+	if err := r.ParseMultipartForm(s.cfg.MaxUploadSize); err != nil {
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	files := r.MultipartForm.File["file"]
+	if len(files) == 0 {
+		http.Error(w, "Bad Request: no files uploaded", http.StatusBadRequest)
+		return
+	}
+
+	if err := os.MkdirAll(s.cfg.WorkRoot, 0o700); err != nil {
+		log.Printf("os.MkdirAll: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	workDir, err := os.MkdirTemp(s.cfg.WorkRoot, scratchDirPrefix)
+	if err != nil {
+		log.Printf("os.MkdirTemp: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	defer os.RemoveAll(workDir)
+
+	paths := make([]string, 0, len(files))
+	for _, fh := range files {
+		if fh.Size > s.cfg.MaxFileSize {
+			http.Error(w, fmt.Sprintf("Request Entity Too Large: %q exceeds the per-file size limit", fh.Filename), http.StatusRequestEntityTooLarge)
+			return
+		}
+		path, err := spoolUpload(workDir, fh)
+		if err != nil {
+			log.Printf("spoolUpload: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		paths = append(paths, path)
+	}
+
+	args := append([]string{"-j", "-a", "-G"}, paths...)
+	et := Run(ctx, s.cfg.ExiftoolPath, args...)
+	defer et.Close()
+	if err := et.Start(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			http.Error(w, "Gateway Timeout", http.StatusGatewayTimeout)
+			return
+		}
+		log.Printf("et.Start: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := et.StreamRaw(w); err != nil {
+		// It's too late to return an HTTP error status, so the best we can
+		// do is log and return, same as GetTags does.
+		if !errors.Is(r.Context().Err(), context.Canceled) {
+			log.Printf("et.StreamRaw: %v", err)
+		}
+		return
+	}
+}
+
+// spoolUpload copies an uploaded multipart file into dir, preserving its
+// original base filename, and returns the resulting path.
+func spoolUpload(dir string, fh *multipart.FileHeader) (string, error) {
+	src, err := fh.Open()
+	if err != nil {
+		return "", fmt.Errorf("fh.Open: %w", err)
+	}
+	defer src.Close()
+
+	path := filepath.Join(dir, filepath.Base(fh.Filename))
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("os.Create: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return "", fmt.Errorf("io.Copy: %w", err)
+	}
+
+	return path, nil
+}
+
+// SweepStaleDirs removes scratch directories under workRoot older than
+// maxAge. It's meant to be called once at startup so directories left
+// behind by a crash (which skips the `defer os.RemoveAll` in
+// ExtractHandler) don't leak disk indefinitely.
+func SweepStaleDirs(workRoot string, maxAge time.Duration) error {
+	entries, err := os.ReadDir(workRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("os.ReadDir: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), scratchDirPrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			log.Printf("entry.Info(%q): %v", entry.Name(), err)
+			continue
+		}
+		if info.ModTime().After(cutoff) {
+			continue
+		}
+		path := filepath.Join(workRoot, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			log.Printf("os.RemoveAll(%q): %v", path, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newUploadFileHeader builds a genuine *multipart.FileHeader carrying
+// filename as an attacker would send it over the wire, so spoolUpload is
+// exercised the same way ExtractHandler's r.ParseMultipartForm would produce
+// it.
+func newUploadFileHeader(t *testing.T, filename, content string) *multipart.FileHeader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": []string{`form-data; name="file"; filename="` + filename + `"`},
+	})
+	if err != nil {
+		t.Fatalf("w.CreatePart: %v", err)
+	}
+	if _, err := part.Write([]byte(content)); err != nil {
+		t.Fatalf("part.Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("w.Close: %v", err)
+	}
+
+	r := multipart.NewReader(&buf, w.Boundary())
+	form, err := r.ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("r.ReadForm: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File["file"][0]
+}
+
+func TestSpoolUploadRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	fh := newUploadFileHeader(t, "../../../etc/cron.d/evil", "payload")
+
+	path, err := spoolUpload(dir, fh)
+	if err != nil {
+		t.Fatalf("spoolUpload: %v", err)
+	}
+
+	if filepath.Dir(path) != dir {
+		t.Fatalf("spoolUpload wrote outside dir: got %q, want a child of %q", path, dir)
+	}
+	if strings.Contains(filepath.Base(path), "..") {
+		t.Fatalf("spoolUpload kept traversal segments in the filename: %q", path)
+	}
+}
@@ -2,50 +2,87 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
+	"strings"
 )
 
-// Handler is HTTP handler function for the application
-func Handler(w http.ResponseWriter, r *http.Request) {
-	switch r.URL.Path {
-	case "/tags":
-		GetTags(w, r)
-		return
-	default:
-		http.Error(w, "Not Found", http.StatusNotFound)
-		return
-	}
-}
-
-// Get tags is HTTP handler function for the /tags GET method
-func GetTags(w http.ResponseWriter, r *http.Request) {
+// GetTags is the HTTP handler function for the /tags GET method. It serves
+// the cached `exiftool -listx` result, honoring If-None-Match with a 304,
+// and renders either a single JSON array or, for clients that ask for
+// application/x-ndjson, one JSON object per tag.
+func (s *Server) GetTags(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	et := Listx(r.Context())
-	defer et.Close()
-	err := et.Start()
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.RequestTimeout)
+	defer cancel()
+
+	entry, err := s.tagsCache.get(ctx)
 	if err != nil {
-		log.Printf("et.Start: %v", err)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			http.Error(w, "Gateway Timeout", http.StatusGatewayTimeout)
+			return
+		}
+		log.Printf("s.tagsCache.get: %v", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("ETag", entry.etag)
+	w.Header().Set("Last-Modified", entry.lastModified.UTC().Format(http.TimeFormat))
 
-	err = et.StreamTags(w)
-	if err != nil {
-		// It's too late to return HTTP 500, so the best we could do is log and return
-		// But first let's check if we've been canceled. If that was the case, no
-		// need to spam logs with errors (which are guaranteed to happen because of the
-		// process being killed and writer forcefully closed)
+	if inm := r.Header.Get("If-None-Match"); inm != "" && inm == entry.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if wantsNDJSON(r) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(w)
+		for _, tag := range entry.tags {
+			if err := enc.Encode(tag); err != nil {
+				// It's too late to return an HTTP error status, so the best
+				// we can do is log and return.
+				if !errors.Is(r.Context().Err(), context.Canceled) {
+					log.Printf("enc.Encode: %v", err)
+				}
+				return
+			}
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(entry.jsonBody); err != nil {
 		if !errors.Is(r.Context().Err(), context.Canceled) {
-			log.Printf("et.StreamTags: %v", err)
+			log.Printf("w.Write: %v", err)
 		}
 		return
 	}
 }
+
+// wantsNDJSON reports whether r's Accept header prefers NDJSON over a
+// single JSON array.
+func wantsNDJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/x-ndjson")
+}
+
+// AdminRefresh is the HTTP handler function for the /admin/refresh POST
+// method. It invalidates the /tags cache so the next request repopulates
+// it, e.g. after upgrading exiftool.
+func (s *Server) AdminRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.RefreshTagsCache()
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Config holds the tunables for constructing a Server. Call DefaultConfig
+// and override individual fields rather than building a Config from
+// scratch.
+type Config struct {
+	// Addr is the address the HTTP server listens on, e.g. ":8080".
+	Addr string
+	// ExiftoolPath is the path to (or name on $PATH of) the exiftool binary
+	// to invoke.
+	ExiftoolPath string
+	// RequestTimeout bounds how long a single /tags request, and the
+	// exiftool process backing it, is allowed to run.
+	RequestTimeout time.Duration
+	// ExtractTimeout bounds how long a single /extract request, and the
+	// exiftool process backing it, is allowed to run.
+	ExtractTimeout time.Duration
+	// MaxConcurrentExtractions caps how many /extract requests may have an
+	// exiftool process running at once.
+	MaxConcurrentExtractions int
+	// MaxUploadSize caps the total size of a /extract request body.
+	MaxUploadSize int64
+	// MaxFileSize caps the size of an individual file uploaded to /extract.
+	MaxFileSize int64
+	// WorkRoot is the directory under which /extract creates its
+	// per-request scratch directories.
+	WorkRoot string
+	// StaleDirMaxAge is how long a scratch directory may live before
+	// SweepStaleDirs removes it.
+	StaleDirMaxAge time.Duration
+}
+
+// DefaultConfig returns the Config exifserve has always run with.
+func DefaultConfig() Config {
+	return Config{
+		Addr:                     ":8080",
+		ExiftoolPath:             "exiftool",
+		RequestTimeout:           30 * time.Second,
+		ExtractTimeout:           2 * time.Minute,
+		MaxConcurrentExtractions: 4,
+		MaxUploadSize:            64 << 20, // 64 MiB
+		MaxFileSize:              32 << 20, // 32 MiB
+		WorkRoot:                 filepath.Join(os.TempDir(), "exifserve"),
+		StaleDirMaxAge:           time.Hour,
+	}
+}
+
+// Server is the exifserve HTTP API: it holds the configuration and the
+// state (the extraction concurrency limiter, the /tags cache) that the
+// individual handler functions need.
+type Server struct {
+	cfg Config
+
+	extractLimiter *ExtractLimiter
+	tagsCache      *tagsCache
+}
+
+// NewServer constructs a Server from cfg, ready to be wired into an
+// http.Server via HTTPServer.
+func NewServer(cfg Config) *Server {
+	return &Server{
+		cfg:            cfg,
+		extractLimiter: NewExtractLimiter(cfg.MaxConcurrentExtractions),
+		tagsCache:      newTagsCache(cfg.ExiftoolPath, cfg.RequestTimeout),
+	}
+}
+
+// ExtractLimiter returns the limiter bounding how many exiftool extraction
+// processes may run at once. The HTTP, gRPC and WebSocket transports all
+// share this one instance so none of them can exceed
+// cfg.MaxConcurrentExtractions on its own; wire it into grpcserver.NewServer
+// alongside the same Config.
+func (s *Server) ExtractLimiter() *ExtractLimiter {
+	return s.extractLimiter
+}
+
+// RefreshTagsCache invalidates the cached /tags response so the next
+// request repopulates it. It's exposed for POST /admin/refresh and for
+// main's SIGHUP handler.
+func (s *Server) RefreshTagsCache() {
+	s.tagsCache.invalidate()
+}
+
+// HTTPServer returns an *http.Server configured with s as its handler and
+// timeouts that keep a wedged client or a stuck exiftool process from
+// pinning a connection open forever.
+func (s *Server) HTTPServer() *http.Server {
+	return &http.Server{
+		Addr:              s.cfg.Addr,
+		Handler:           http.HandlerFunc(s.Handler),
+		ReadHeaderTimeout: 5 * time.Second,
+		// WriteTimeout is a coarse outer bound on top of the finer-grained
+		// per-request context deadlines (RequestTimeout/ExtractTimeout)
+		// applied inside the handlers themselves.
+		WriteTimeout: 5 * time.Minute,
+		IdleTimeout:  90 * time.Second,
+	}
+}
+
+// Handler is the HTTP handler function for the application
+func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/tags":
+		s.GetTags(w, r)
+		return
+	case "/extract":
+		s.ExtractHandler(w, r)
+		return
+	case "/ws/tags":
+		s.WSTags(w, r)
+		return
+	case "/ws/extract":
+		s.WSExtract(w, r)
+		return
+	case "/admin/refresh":
+		s.AdminRefresh(w, r)
+		return
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsUpgrader upgrades /ws/tags and /ws/extract connections. exifserve has no
+// browser-facing auth story yet, so (like the plain HTTP endpoints) it
+// accepts connections from any origin.
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsClientMessage is the shape of every client->server control message sent
+// over /ws/tags or /ws/extract.
+type wsClientMessage struct {
+	Type     string `json:"type"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// wsFileResult is the shape of a per-file result pushed over /ws/extract.
+type wsFileResult struct {
+	Filename string            `json:"filename"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// WSTags is the WebSocket handler for /ws/tags. It pushes one JSON message
+// per Tag as soon as exiftool's `-listx` output decodes it, so a client
+// doesn't have to buffer the whole array GetTags sends to see the first
+// result. Sending {"type":"cancel"} aborts the stream early.
+func (s *Server) WSTags(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("wsUpgrader.Upgrade: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.RequestTimeout)
+	defer cancel()
+
+	go watchForCancel(conn, cancel)
+
+	et := Listx(ctx, s.cfg.ExiftoolPath)
+	defer et.Close()
+	if err := et.Start(); err != nil {
+		log.Printf("et.Start: %v", err)
+		return
+	}
+
+	streamErr := et.StreamTagsFunc(func(tag Tag) error {
+		tagJSON, err := json.Marshal(tag)
+		if err != nil {
+			return fmt.Errorf("json.Marshal: %w", err)
+		}
+		return conn.WriteMessage(websocket.TextMessage, tagJSON)
+	})
+	if streamErr != nil && !errors.Is(ctx.Err(), context.Canceled) {
+		log.Printf("et.StreamTagsFunc: %v", streamErr)
+	}
+}
+
+// WSExtract is the WebSocket handler for /ws/extract. The client uploads
+// each file as a {"type":"file","filename":...} message followed by one or
+// more binary frames and a {"type":"eof"} message, then a
+// {"type":"extract"} message to trigger exiftool; WSExtract spools the
+// frames to a scratch directory exactly like ExtractHandler does and pushes
+// back one JSON result per file once exiftool has finished with all of them.
+// Sending {"type":"cancel"} aborts the stream early.
+func (s *Server) WSExtract(w http.ResponseWriter, r *http.Request) {
+	release, ok := s.extractLimiter.TryAcquire()
+	if !ok {
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	}
+	defer release()
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("wsUpgrader.Upgrade: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.ExtractTimeout)
+	defer cancel()
+
+	if err := os.MkdirAll(s.cfg.WorkRoot, 0o700); err != nil {
+		log.Printf("os.MkdirAll: %v", err)
+		return
+	}
+	workDir, err := os.MkdirTemp(s.cfg.WorkRoot, scratchDirPrefix)
+	if err != nil {
+		log.Printf("os.MkdirTemp: %v", err)
+		return
+	}
+	defer os.RemoveAll(workDir)
+
+	paths, err := wsSpoolUploads(conn, workDir, s.cfg.MaxFileSize, s.cfg.MaxUploadSize)
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		log.Printf("wsSpoolUploads: %v", err)
+		return
+	}
+
+	// wsSpoolUploads is done reading conn, so it's safe to hand reading off
+	// to watchForCancel for the rest of the request - otherwise a cancel
+	// message sent while exiftool is running (the expensive part) would
+	// never be observed.
+	go watchForCancel(conn, cancel)
+
+	args := append([]string{"-j", "-a", "-G"}, paths...)
+	et := Run(ctx, s.cfg.ExiftoolPath, args...)
+	defer et.Close()
+	if err := et.Start(); err != nil {
+		log.Printf("et.Start: %v", err)
+		return
+	}
+
+	var out bytes.Buffer
+	streamErr := et.StreamRaw(&out)
+	if streamErr != nil {
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			log.Printf("et.StreamRaw: %v", streamErr)
+		}
+		return
+	}
+
+	results, err := decodeFileResults(out.Bytes())
+	if err != nil {
+		log.Printf("decodeFileResults: %v", err)
+		return
+	}
+	for _, res := range results {
+		resJSON, err := json.Marshal(res)
+		if err != nil {
+			log.Printf("json.Marshal: %v", err)
+			return
+		}
+		if err := conn.WriteMessage(websocket.TextMessage, resJSON); err != nil {
+			log.Printf("conn.WriteMessage: %v", err)
+			return
+		}
+	}
+}
+
+// watchForCancel is the sole reader of conn (gorilla/websocket allows only
+// one goroutine to read at a time). It exits, canceling ctx, as soon as the
+// client sends {"type":"cancel"} or the connection is closed.
+func watchForCancel(conn *websocket.Conn, cancel context.CancelFunc) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			cancel()
+			return
+		}
+		var msg wsClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		if msg.Type == "cancel" {
+			cancel()
+			return
+		}
+	}
+}
+
+// wsSpoolUploads is WSExtract's sole reader of conn. It follows the
+// {"type":"file"}/binary-frames/{"type":"eof"} protocol described on
+// WSExtract until it sees {"type":"extract"}, {"type":"cancel"}, or the
+// connection closes, and returns the spooled file paths in upload order.
+// Unlike the HTTP /extract path, there's no Content-Length to cap with
+// http.MaxBytesReader up front, so maxUploadSize is enforced as a running
+// total across every file in the upload instead.
+func wsSpoolUploads(conn *websocket.Conn, dir string, maxFileSize, maxUploadSize int64) ([]string, error) {
+	var paths []string
+	var current *os.File
+	var currentSize, totalSize int64
+	defer func() {
+		if current != nil {
+			current.Close()
+		}
+	}()
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			return nil, fmt.Errorf("conn.ReadMessage: %w", err)
+		}
+
+		if msgType == websocket.BinaryMessage {
+			if current == nil {
+				return nil, errors.New("binary frame received before a \"file\" message")
+			}
+			currentSize += int64(len(data))
+			if currentSize > maxFileSize {
+				return nil, fmt.Errorf("upload exceeds the per-file size limit of %d bytes", maxFileSize)
+			}
+			totalSize += int64(len(data))
+			if totalSize > maxUploadSize {
+				return nil, fmt.Errorf("upload exceeds the total size limit of %d bytes", maxUploadSize)
+			}
+			if _, err := current.Write(data); err != nil {
+				return nil, fmt.Errorf("current.Write: %w", err)
+			}
+			continue
+		}
+
+		var msg wsClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+
+		switch msg.Type {
+		case "file":
+			if current != nil {
+				current.Close()
+			}
+			path := filepath.Join(dir, filepath.Base(msg.Filename))
+			current, err = os.Create(path)
+			if err != nil {
+				return nil, fmt.Errorf("os.Create: %w", err)
+			}
+			currentSize = 0
+			paths = append(paths, path)
+		case "eof":
+			if current != nil {
+				current.Close()
+				current = nil
+			}
+		case "extract":
+			return paths, nil
+		case "cancel":
+			return nil, context.Canceled
+		}
+	}
+}
+
+// decodeFileResults parses the JSON array exiftool's `-j` produces into one
+// wsFileResult per file, flattening each file's tag values to strings.
+func decodeFileResults(jsonOutput []byte) ([]wsFileResult, error) {
+	var files []map[string]interface{}
+	if err := json.Unmarshal(jsonOutput, &files); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	results := make([]wsFileResult, 0, len(files))
+	for _, file := range files {
+		res := wsFileResult{Tags: make(map[string]string, len(file))}
+		for k, v := range file {
+			if k == "SourceFile" {
+				res.Filename = filepath.Base(fmt.Sprintf("%v", v))
+				continue
+			}
+			res.Tags[k] = fmt.Sprintf("%v", v)
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
@@ -3,18 +3,59 @@ package main
 import (
 	"log"
 	"net"
-	"net/http"
-	"strconv"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"google.golang.org/grpc"
+
+	"github.com/anton-dessiatov/exifserve/grpcserver"
 	"github.com/anton-dessiatov/exifserve/handler"
+	"github.com/anton-dessiatov/exifserve/proto"
 )
 
-const Port = 8080
+// GRPCAddr is the address the gRPC server listens on, alongside the HTTP
+// server's cfg.Addr.
+const GRPCAddr = ":8081"
 
 func main() {
-	err := http.ListenAndServe(net.JoinHostPort("", strconv.Itoa(Port)),
-		http.HandlerFunc(handler.Handler))
+	cfg := handler.DefaultConfig()
+
+	if err := handler.SweepStaleDirs(cfg.WorkRoot, cfg.StaleDirMaxAge); err != nil {
+		log.Printf("handler.SweepStaleDirs: %v", err)
+	}
+
+	srv := handler.NewServer(cfg)
+
+	go runGRPCServer(cfg, srv.ExtractLimiter())
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Printf("received SIGHUP, refreshing /tags cache")
+			srv.RefreshTagsCache()
+		}
+	}()
+
+	httpServer := srv.HTTPServer()
+	log.Printf("HTTP listening on %s", httpServer.Addr)
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Fatalf("httpServer.ListenAndServe: %v", err)
+	}
+}
+
+func runGRPCServer(cfg handler.Config, limiter *handler.ExtractLimiter) {
+	lis, err := net.Listen("tcp", GRPCAddr)
 	if err != nil {
-		log.Fatalf("http.ListenAndServe: %v", err)
+		log.Fatalf("net.Listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	proto.RegisterExifServer(grpcServer, grpcserver.NewServer(cfg, limiter))
+
+	log.Printf("gRPC listening on %s", GRPCAddr)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("grpcServer.Serve: %v", err)
 	}
 }
@@ -0,0 +1,11 @@
+// Package proto holds the exifserve gRPC service definition and its
+// generated Go bindings.
+//
+// Regenerate the bindings after editing exif.proto with:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	    --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//	    proto/exif.proto
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative exif.proto